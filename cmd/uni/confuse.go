@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"zgo.at/uni/v2/unidata"
+	"zgo.at/zli"
+)
+
+// cmdConfuse implements "uni confuse <string>": print each codepoint in the
+// argument together with its visually-confusable alternatives and
+// IdentifierStatus, so users can spot spoofed identifiers (see
+// unidata.Skeleton).
+func cmdConfuse(args []string) error {
+	f := zli.NewFlags(append([]string{"uni confuse"}, args...))
+	if err := f.Parse(); err != nil {
+		return err
+	}
+	if len(f.Args) == 0 {
+		return fmt.Errorf("uni confuse: need a string to check")
+	}
+
+	for _, s := range f.Args {
+		for _, r := range s {
+			status := "allowed"
+			if unidata.IdentifierStatus[r] == unidata.IdentifierRestricted {
+				status = "restricted"
+			}
+
+			alts := unidata.Alternatives(r)
+			if len(alts) == 0 {
+				fmt.Printf("%-4U %c   %-10s no known confusables\n", r, r, status)
+				continue
+			}
+			fmt.Printf("%-4U %c   %-10s confusable with: %s\n", r, r, status, formatAlts(alts))
+		}
+	}
+	return nil
+}
+
+// formatAlts renders alts as "U+0041 A, U+0391 Α, ..." for cmdConfuse's
+// output.
+func formatAlts(alts []rune) string {
+	var b strings.Builder
+	for i, r := range alts {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%-4U %c", r, r)
+	}
+	return b.String()
+}
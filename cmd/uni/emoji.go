@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"zgo.at/uni/v2/unidata"
+	"zgo.at/zli"
+)
+
+// cmdEmoji implements "uni emoji"/"uni e <query>": search emoji by name or
+// CLDR keyword, optionally selecting a skin tone and/or gender.
+//
+// -t/-g may be repeated to set a distinct tone/gender per slot, left to
+// right (e.g. "-t dark -t light" on a two-sided sequence like handshake).
+// A single -t/-g value containing commas instead expands to one result per
+// value, each applied uniformly to every slot (e.g. "-t light,dark" prints
+// the emoji twice, once all-light and once all-dark).
+//
+// -lang restricts keyword matching to a single CLDR locale (e.g. "-lang de
+// Katze" finds 🐈 via its German annotations); by default every locale
+// unidata.Locales carries annotations for is searched.
+func cmdEmoji(args []string) error {
+	f := zli.NewFlags(append([]string{"uni emoji"}, args...))
+	tones := f.StringList(nil, "t", "tone")
+	genders := f.StringList(nil, "g", "gender")
+	lang := f.String("", "lang")
+	if err := f.Parse(); err != nil {
+		return err
+	}
+	if len(f.Args) == 0 {
+		return fmt.Errorf("uni emoji: need a search query")
+	}
+	query := strings.Join(f.Args, " ")
+
+	var locales []string
+	if l := lang.String(); l != "" {
+		locales = []string{l}
+	}
+
+	for _, e := range unidata.Emojis {
+		if !e.MatchesKeyword(query, locales...) {
+			continue
+		}
+		for _, cps := range selections(e, tones.Strings(), genders.Strings()) {
+			fmt.Printf("%s   %s\n", string(cps), e.Name)
+		}
+	}
+	return nil
+}
+
+// selections applies the requested tone(s) and gender(s) to e and returns
+// every resulting codepoint sequence to print. Repeated flags ("-t dark -t
+// light") select one value per slot in a single result; a single
+// comma-separated value ("-t light,dark") instead expands to one
+// uniformly-applied result per value. Tone and gender selection compose: the
+// gender is applied on top of whichever tone variant was picked.
+func selections(e unidata.Emoji, toneNames, genderNames []string) [][]rune {
+	tones := expandNames(toneNames)
+	if len(tones) == 0 {
+		tones = [][]rune{nil}
+	}
+	genders := expandNames(genderNames)
+	if len(genders) == 0 {
+		genders = [][]rune{nil}
+	}
+
+	var out [][]rune
+	for _, t := range tones {
+		toned := e.Codepoints
+		genderAt := e.GenderAt
+		if e.ToneSlots > 0 && len(t) > 0 {
+			toned = e.WithTone(mod(t, unidata.ToneModifiers)...)
+			// e.GenderAt was computed against the un-toned e.Codepoints; each
+			// tone WithTone wove in shifts every later index by one, so
+			// re-derive the gender positions against the toned sequence.
+			genderAt = shiftAt(genderAt, e.ToneAt)
+		}
+		for _, g := range genders {
+			cps := toned
+			if e.GenderSlots > 0 && len(g) > 0 {
+				cps = unidata.Emoji{Codepoints: toned, GenderSlots: e.GenderSlots, GenderAt: genderAt}.
+					WithGender(mod(g, unidata.GenderModifiers)...)
+			}
+			out = append(out, cps)
+		}
+	}
+	return out
+}
+
+// shiftAt re-derives the insertion positions in at (e.g. GenderAt) after a
+// rune has already been woven into the sequence at each position in woven
+// (e.g. ToneAt): every position at or after a given woven insertion point
+// shifts right by one.
+func shiftAt(at, woven []int) []int {
+	if len(woven) == 0 {
+		return at
+	}
+
+	out := make([]int, len(at))
+	for i, a := range at {
+		shift := 0
+		for _, w := range woven {
+			if w <= a {
+				shift++
+			}
+		}
+		out[i] = a + shift
+	}
+	return out
+}
+
+// expandNames splits repeated -t/-g occurrences into per-slot selections
+// (one []string per result), or a single comma-separated occurrence into one
+// []string{name} per value.
+func expandNames(names []string) [][]string {
+	if len(names) == 0 {
+		return nil
+	}
+	if len(names) == 1 && strings.Contains(names[0], ",") {
+		var out [][]string
+		for _, n := range strings.Split(names[0], ",") {
+			out = append(out, []string{n})
+		}
+		return out
+	}
+	return [][]string{names}
+}
+
+func mod(names []string, mods map[string]rune) []rune {
+	rs := make([]rune, 0, len(names))
+	for _, n := range names {
+		rs = append(rs, mods[n])
+	}
+	return rs
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"zgo.at/uni/v2/unidata"
+	"zgo.at/zli"
+)
+
+// cmdIdentify implements "uni identify <string>": print every codepoint in
+// the argument together with its name and properties. -xref additionally
+// prints the NamesList.txt aliases, comments, and cross-references attached
+// to each codepoint. -lang selects which CLDR locale's emoji TTS name is
+// shown for codepoints that are also a (single-codepoint) emoji; by default
+// every locale unidata.Locales carries annotations for is shown.
+func cmdIdentify(args []string) error {
+	f := zli.NewFlags(append([]string{"uni identify"}, args...))
+	xref := f.Bool(false, "xref")
+	lang := f.String("", "lang")
+	if err := f.Parse(); err != nil {
+		return err
+	}
+	if len(f.Args) == 0 {
+		return fmt.Errorf("uni identify: need a string to identify")
+	}
+
+	locales := unidata.Locales
+	if l := lang.String(); l != "" {
+		locales = []string{l}
+	}
+
+	for _, s := range f.Args {
+		for _, r := range s {
+			cp, ok := unidata.Codepoints[r]
+			if !ok {
+				fmt.Printf("%-8U %c   (no data)\n", r, r)
+				continue
+			}
+			fmt.Printf("%-8U %c   %s\n", r, r, cp.Name)
+
+			for _, e := range unidata.Emojis {
+				if len(e.Codepoints) != 1 || e.Codepoints[0] != r {
+					continue
+				}
+				for _, locale := range locales {
+					if tts, ok := e.TTS[locale]; ok {
+						fmt.Printf("         [%s] %s\n", locale, tts)
+					}
+				}
+			}
+
+			if !xref.Bool() {
+				continue
+			}
+			for _, a := range cp.Aliases {
+				fmt.Printf("         = %s\n", a)
+			}
+			for _, c := range cp.Comments {
+				fmt.Printf("         * %s\n", c)
+			}
+			for _, see := range cp.SeeAlso {
+				if seeCp, ok := unidata.Codepoints[see]; ok {
+					fmt.Printf("         x %-8U %s\n", see, seeCp.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Command uni looks up Unicode codepoints, emoji, and related data from the
+// unidata package.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"zgo.at/zli"
+)
+
+const usage = `uni: explore Unicode codepoints, properties, and emoji.
+
+Usage: uni <command> [flags] [args]
+
+Commands:
+    identify    Identify every codepoint in a string
+    confuse     Show visually-confusable alternatives for a string
+    emoji, e    Search and compose emoji
+    p           List codepoints with a Unicode property
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		zli.Exit(1)
+	}
+
+	cmd, args := os.Args[1], os.Args[2:]
+	var err error
+	switch cmd {
+	case "identify":
+		err = cmdIdentify(args)
+	case "confuse":
+		err = cmdConfuse(args)
+	case "emoji", "e":
+		err = cmdEmoji(args)
+	case "p":
+		err = cmdProperties(args)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "uni: unknown command %q\n\n%s", cmd, usage)
+		zli.Exit(1)
+	}
+	zli.F(err)
+}
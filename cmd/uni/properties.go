@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"zgo.at/uni/v2/unidata"
+	"zgo.at/zli"
+)
+
+// cmdProperties implements "uni p <name>": print every codepoint with the
+// given Unicode property, e.g. "uni p dash", "uni p Emoji_Presentation", or
+// "uni p 'Script=Greek'". name may be an alias (e.g. "WSpace" for
+// "White_Space"); see unidata.CodepointsWithProperty.
+func cmdProperties(args []string) error {
+	f := zli.NewFlags(append([]string{"uni p"}, args...))
+	if err := f.Parse(); err != nil {
+		return err
+	}
+	if len(f.Args) != 1 {
+		return fmt.Errorf("uni p: need exactly one property name")
+	}
+
+	name := f.Args[0]
+	if _, ok := unidata.ResolveProperty(name); !ok {
+		return fmt.Errorf("uni p: unknown property %q", name)
+	}
+
+	for _, r := range unidata.CodepointsWithProperty(name) {
+		if d, ok := unidata.Codepoints[r]; ok {
+			fmt.Printf("%-8U %c   %s\n", r, r, d.Name)
+		} else {
+			fmt.Printf("%-8U %c\n", r, r)
+		}
+	}
+	return nil
+}
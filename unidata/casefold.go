@@ -0,0 +1,26 @@
+package unidata
+
+import "strings"
+
+// FoldString applies full Unicode case folding to s, so it can be compared
+// for caseless matching. This uses the "C"+"F" mappings from CaseFolding.txt,
+// which includes multi-codepoint expansions (e.g. "ß" -> "ss").
+func FoldString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if fold, ok := CaseFold[r]; ok {
+			for _, f := range fold {
+				b.WriteRune(f)
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// EqualFold reports whether a and b are equal under full Unicode case
+// folding; unlike strings.EqualFold this also handles the multi-codepoint
+// expansions in CaseFold (e.g. "ß" == "SS").
+func EqualFold(a, b string) bool { return FoldString(a) == FoldString(b) }
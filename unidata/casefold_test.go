@@ -0,0 +1,35 @@
+package unidata
+
+import "testing"
+
+// FoldString uses the "C"+"F" CaseFolding.txt statuses only; the Turkic "T"
+// status is deliberately skipped (see loadcasefold in gen.go), so Turkish
+// dotless/dotted i behaves like everywhere else: 'I' folds to 'i', not the
+// Turkish dotless 'ı', and 'İ' folds to "i" + combining dot above, not a
+// plain 'i'.
+func TestFoldStringTurkish(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"I", "i"},
+		{"İ", "i̇"},
+		{"ıI", "ıi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := FoldString(tt.in)
+			if got != tt.want {
+				t.Errorf("FoldString(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualFoldTurkish(t *testing.T) {
+	if !EqualFold("I", "i") {
+		t.Error("EqualFold(I, i) = false, want true (T status is skipped)")
+	}
+	if EqualFold("I", "ı") {
+		t.Error("EqualFold(I, ı) = true, want false (Turkish dotless i is not applied)")
+	}
+}
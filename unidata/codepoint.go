@@ -0,0 +1,26 @@
+package unidata
+
+// Codepoint is a single Unicode codepoint and the various properties we know
+// about it.
+//
+// This is populated from gen_codepoints.go, which is generated by gen.go from
+// the Unicode Character Database; see that file for more details on where the
+// individual fields come from.
+type Codepoint struct {
+	Codepoint rune
+	Width     uint8
+	Cat       uint8
+	Name      string
+	Digraph   string
+	Entity    string
+	Keysym    string
+	CaseFold  []rune
+	Aliases   []string
+	Comments  []string
+	SeeAlso   []rune
+}
+
+// Properties returns the Unicode properties (as listed in PropList.txt,
+// DerivedCoreProperties.txt, emoji-data.txt, Scripts.txt,
+// ScriptExtensions.txt, and Blocks.txt) that apply to this codepoint.
+func (c Codepoint) Properties() []string { return PropertiesOf(c.Codepoint) }
@@ -0,0 +1,89 @@
+package unidata
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Identifier status, as per IdentifierStatus.txt; codepoints marked
+// Restricted shouldn't be mixed with Allowed ones in a single identifier.
+const (
+	IdentifierAllowed uint8 = iota
+	IdentifierRestricted
+)
+
+// Skeleton computes the UTS #39 "skeleton" of s: a normal form where
+// visually confusable strings map to the same skeleton. This is: NFD, apply
+// the Confusables mapping to every codepoint, and NFD again.
+func Skeleton(s string) string {
+	s = norm.NFD.String(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if target, ok := Confusables[r]; ok {
+			for _, t := range target {
+				b.WriteRune(t)
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return norm.NFD.String(b.String())
+}
+
+// Confusable reports whether a and b are visually confusable with each
+// other, i.e. whether they have the same skeleton.
+func Confusable(a, b string) bool { return Skeleton(a) == Skeleton(b) }
+
+// confusableGroups maps a skeleton (as a string of target runes) to every
+// codepoint confusable with it, so Alternatives can go the other way around:
+// from a codepoint to the other codepoints it's confusable with, rather than
+// just its own skeleton. This includes both the Confusables source
+// codepoints and, for single-rune skeletons, the canonical target itself
+// (which is never a key in Confusables, since it's already its own
+// skeleton) -- otherwise looking up a confusable of "a" would list every
+// other look-alike but never the real "a".
+var confusableGroups = func() map[string][]rune {
+	groups := make(map[string][]rune)
+	seen := make(map[string]map[rune]bool)
+	add := func(key string, r rune) {
+		if seen[key] == nil {
+			seen[key] = make(map[rune]bool)
+		}
+		if seen[key][r] {
+			return
+		}
+		seen[key][r] = true
+		groups[key] = append(groups[key], r)
+	}
+	for r, target := range Confusables {
+		key := string(target)
+		add(key, r)
+		if len(target) == 1 {
+			add(key, target[0])
+		}
+	}
+	return groups
+}()
+
+// Alternatives returns every codepoint visually confusable with r: the other
+// codepoints that share r's skeleton, per confusables.txt.
+func Alternatives(r rune) []rune {
+	key := string(r)
+	if target, ok := Confusables[r]; ok {
+		key = string(target)
+	}
+
+	var out []rune
+	for _, alt := range confusableGroups[key] {
+		if alt != r {
+			out = append(out, alt)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
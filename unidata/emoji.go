@@ -0,0 +1,148 @@
+package unidata
+
+import "strings"
+
+// Gender a single-person emoji is presented as.
+const (
+	GenderNone = 0
+	GenderSign = 1
+	GenderRole = 2
+)
+
+// Locales are the CLDR locales Emoji.CLDR/Emoji.TTS carry annotations for;
+// gen.go fetches exactly these.
+var Locales = []string{"en", "de", "fr", "ja", "zh", "es", "ru"}
+
+// ToneModifiers maps the Fitzpatrick skin tone modifier names used on the
+// command line to their codepoint, for use with Emoji.WithTone.
+var ToneModifiers = map[string]rune{
+	"light":        0x1F3FB,
+	"medium-light": 0x1F3FC,
+	"medium":       0x1F3FD,
+	"medium-dark":  0x1F3FE,
+	"dark":         0x1F3FF,
+}
+
+// GenderModifiers maps the gender names used on the command line to their
+// codepoint, for use with Emoji.WithGender.
+var GenderModifiers = map[string]rune{
+	"male":   0x2642, // MALE SIGN
+	"female": 0x2640, // FEMALE SIGN
+}
+
+// Emoji is a single emoji, as listed in emoji-test.txt, combined with its
+// CLDR annotations.
+//
+// This is populated from gen_emojis.go, which is generated by gen.go; see
+// that file for more details on where the individual fields come from.
+type Emoji struct {
+	Codepoints []rune
+	Name       string
+	Group      int
+	Subgroup   int
+
+	// CLDR holds the search keywords for this emoji, keyed by locale tag
+	// (e.g. "en", "de"); TTS holds the short ("type-to-speech") name for the
+	// same locales. Both are populated from CLDR's annotations and
+	// annotationsDerived data.
+	CLDR map[string][]string
+	TTS  map[string]string
+
+	// ToneSlots is the number of independently tone-able positions in this
+	// emoji: 0 if it has no skin tone variants, 1 for the common case of a
+	// single person/body part, or 2 for two-sided sequences such as
+	// "handshake" or "people holding hands" where each side can have its own
+	// tone. ToneAt holds, for each tone slot in order, how many Codepoints
+	// the tone follows (it's inserted right after Codepoints[at-1]).
+	ToneSlots int
+	ToneAt    []int
+
+	// Genders describes whether this emoji has an explicitly gendered
+	// variant (GenderSign, via the female/male sign) or is one of the
+	// "person" role emoji that can be presented as a man or woman
+	// (GenderRole). GenderSlots/GenderAt mirror ToneSlots/ToneAt for
+	// multi-person sequences (e.g. "woman and man holding hands") where each
+	// side can be gendered independently.
+	Genders     int
+	GenderSlots int
+	GenderAt    []int
+}
+
+// String returns the emoji as a string of its codepoints.
+func (e Emoji) String() string { return string(e.Codepoints) }
+
+// MatchesKeyword reports whether q (case-insensitively) matches this emoji's
+// name, or one of its CLDR keywords/TTS name in any of the given locales. An
+// empty locales matches every locale CLDR data was fetched for.
+func (e Emoji) MatchesKeyword(q string, locales ...string) bool {
+	q = strings.ToLower(q)
+	if strings.Contains(strings.ToLower(e.Name), q) {
+		return true
+	}
+	if len(locales) == 0 {
+		locales = Locales
+	}
+	for _, locale := range locales {
+		if strings.Contains(strings.ToLower(e.TTS[locale]), q) {
+			return true
+		}
+		for _, kw := range e.CLDR[locale] {
+			if strings.Contains(strings.ToLower(kw), q) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithTone returns the codepoints for this emoji with a skin tone modifier
+// applied at each tone slot. tones is applied one-per-slot, left to right;
+// if fewer tones than ToneSlots are given the last one is repeated, so a
+// single tone applies uniformly to every slot.
+func (e Emoji) WithTone(tones ...rune) []rune {
+	if e.ToneSlots == 0 || len(tones) == 0 {
+		return e.Codepoints
+	}
+
+	out := make([]rune, 0, len(e.Codepoints)+len(e.ToneAt))
+	t := 0
+	for i, cp := range e.Codepoints {
+		out = append(out, cp)
+		for _, at := range e.ToneAt {
+			if at == i+1 {
+				tone := tones[t]
+				if t < len(tones)-1 {
+					t++
+				}
+				out = append(out, tone)
+			}
+		}
+	}
+	return out
+}
+
+// WithGender returns the codepoints for this emoji with a gender presentation
+// applied at each gender slot. genders is applied one-per-slot, left to
+// right; if fewer genders than GenderSlots are given the last one is
+// repeated, so a single gender applies uniformly to every slot.
+func (e Emoji) WithGender(genders ...rune) []rune {
+	if e.GenderSlots == 0 || len(genders) == 0 {
+		return e.Codepoints
+	}
+
+	out := make([]rune, 0, len(e.Codepoints)+len(e.GenderAt))
+	g := 0
+	for i, cp := range e.Codepoints {
+		out = append(out, cp)
+		for _, at := range e.GenderAt {
+			if at == i+1 {
+				gender := genders[g]
+				if g < len(genders)-1 {
+					g++
+				}
+				out = append(out, gender)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package unidata
+
+import "testing"
+
+// handshake is the directional handshake emoji (E14.0), which has two
+// independently tone-able sides: RIGHTWARDS HAND, ZWJ, LEFTWARDS HAND.
+var handshake = Emoji{
+	Codepoints: []rune{0x1FAF1, 0x200D, 0x1FAF2},
+	Name:       "handshake",
+	ToneSlots:  2,
+	ToneAt:     []int{1, 3},
+}
+
+// TestEmojiWithToneHandshake checks that "uni e handshake -t dark -t light"
+// (i.e. WithTone(dark, light)) selects a distinct tone per side rather than
+// dropping the sequence or applying one tone to both, per the repeated
+// -t/-g flag semantics.
+func TestEmojiWithToneHandshake(t *testing.T) {
+	got := string(handshake.WithTone(ToneModifiers["dark"], ToneModifiers["light"]))
+	want := "🫱🏿‍🫲🏻"
+	if got != want {
+		t.Errorf("WithTone(dark, light) = %q, want %q", got, want)
+	}
+}
+
+func TestEmojiWithToneUniform(t *testing.T) {
+	got := string(handshake.WithTone(ToneModifiers["medium"]))
+	want := "🫱🏽‍🫲🏽"
+	if got != want {
+		t.Errorf("WithTone(medium) = %q, want %q (single tone repeats across slots)", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package unidata
+
+// GBProp returns the Grapheme_Cluster_Break property of r, as used by UAX #29
+// grapheme cluster segmentation (see unidata/width). Extended_Pictographic is
+// reported here too, even though it isn't strictly a Grapheme_Cluster_Break
+// value, since GB11 needs it.
+//
+// GBPRanges is only sorted by Lo: Extended_Pictographic ranges (from
+// emoji-data.txt) routinely overlap the Grapheme_Cluster_Break ranges (from
+// GraphemeBreakProperty.txt), e.g. a codepoint can be both Extend and
+// Extended_Pictographic, so Hi isn't monotonic and this has to scan every
+// range rather than binary-searching down to a single start point.
+func GBProp(r rune) uint8 {
+	prop := uint8(GBPOther)
+	for _, rng := range GBPRanges {
+		if rng.Lo <= r && r <= rng.Hi {
+			// Extended_Pictographic is informational on top of the "real"
+			// Grapheme_Cluster_Break category, so prefer any non-Other GCB
+			// value already found over it.
+			if rng.Prop == GBPExtendedPictographic && prop != GBPOther {
+				continue
+			}
+			prop = rng.Prop
+		}
+	}
+	return prop
+}
@@ -33,6 +33,10 @@ func main() {
 
 	zli.F(run("codepoints"))
 	zli.F(run("emojis"))
+	zli.F(run("properties"))
+	zli.F(run("casefold"))
+	zli.F(run("confusables"))
+	zli.F(run("gbp"))
 }
 
 func run(which string) error {
@@ -41,6 +45,14 @@ func run(which string) error {
 		return mkcodepoints()
 	case "emojis":
 		return mkemojis()
+	case "properties":
+		return mkproperties()
+	case "casefold":
+		return mkcasefold()
+	case "confusables":
+		return mkconfusables()
+	case "gbp":
+		return mkgbp()
 	default:
 		return fmt.Errorf("unknown file: %q\n", which)
 	}
@@ -51,33 +63,118 @@ func write(fp io.Writer, s string, args ...interface{}) {
 	zli.F(err)
 }
 
-func readCLDR() map[string][]string {
-	d, err := fetch("https://raw.githubusercontent.com/unicode-org/cldr/master/common/annotations/en.xml")
-	zli.F(err)
+// intsjoin/intsparse round-trip the []int slot positions (ToneAt, GenderAt)
+// through the intermediate []string record in mkemojis()'s emojis map.
+func intsjoin(is []int) string {
+	s := make([]string, len(is))
+	for i, n := range is {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
 
-	var cldr struct {
-		Annotations []struct {
-			CP    string `xml:"cp,attr"`
-			Type  string `xml:"type,attr"`
-			Names string `xml:",innerxml"`
-		} `xml:"annotations>annotation"`
+func intsparse(s string) []int {
+	if s == "" {
+		return nil
+	}
+	var out []int
+	for _, p := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(p)
+		zli.F(err)
+		out = append(out, n)
 	}
-	zli.F(xml.Unmarshal(d, &cldr))
+	return out
+}
 
-	out := make(map[string][]string)
-	for _, a := range cldr.Annotations {
-		if a.Type != "tts" {
-			out[a.CP] = strings.Split(a.Names, " | ")
+// weaveZWJ splices a ZWJ back in at every position recorded in zwjAt (using
+// the same "after this many already-emitted codepoints" convention as
+// ToneAt/GenderAt) and reindexes toneAt/genderAt to match the result.
+//
+// The ZWJ is dropped from cp while parsing emoji-test.txt so the lookup key
+// that merges skin-tone/gender variants onto their base entry stays stable,
+// but two-sided ZWJ sequences like "handshake" or "people holding hands" need
+// the joiner to actually be part of Codepoints -- without it they render as
+// two unjoined emoji rather than one ligated sequence.
+func weaveZWJ(cp []rune, toneAt, genderAt, zwjAt []int) (woven []rune, newToneAt, newGenderAt []int) {
+	if len(zwjAt) == 0 {
+		return cp, toneAt, genderAt
+	}
+
+	// A ZWJ and a tone/gender slot can share the same "after" position (the
+	// tone right after a person, immediately followed by the joiner); the
+	// tone always comes first, so only count ZWJs strictly before at.
+	shift := func(at int) int {
+		n := 0
+		for _, z := range zwjAt {
+			if z < at {
+				n++
+			}
 		}
+		return n
 	}
-	return out
+	for _, at := range toneAt {
+		newToneAt = append(newToneAt, at+shift(at))
+	}
+	for _, at := range genderAt {
+		newGenderAt = append(newGenderAt, at+shift(at))
+	}
+
+	for i, r := range cp {
+		woven = append(woven, r)
+		for _, z := range zwjAt {
+			if z == i+1 {
+				woven = append(woven, 0x200d)
+			}
+		}
+	}
+	return woven, newToneAt, newGenderAt
+}
+
+// readCLDR fetches both the regular and "derived" (sequence-only)
+// annotations for every locale in unidata.Locales, returning the search keywords
+// and the short ("tts") name, each keyed by locale and then by the
+// codepoint sequence as it appears in the CLDR XML (e.g. "🐈" or
+// "🐈‍⬛").
+func readCLDR() (keywords map[string]map[string][]string, tts map[string]map[string]string) {
+	keywords = make(map[string]map[string][]string)
+	tts = make(map[string]map[string]string)
+
+	for _, locale := range unidata.Locales {
+		kw := make(map[string][]string)
+		tt := make(map[string]string)
+		for _, dir := range []string{"annotations", "annotationsDerived"} {
+			d, err := fetch(fmt.Sprintf(
+				"https://raw.githubusercontent.com/unicode-org/cldr/master/common/%s/%s.xml", dir, locale))
+			zli.F(err)
+
+			var cldr struct {
+				Annotations []struct {
+					CP    string `xml:"cp,attr"`
+					Type  string `xml:"type,attr"`
+					Names string `xml:",innerxml"`
+				} `xml:"annotations>annotation"`
+			}
+			zli.F(xml.Unmarshal(d, &cldr))
+
+			for _, a := range cldr.Annotations {
+				if a.Type == "tts" {
+					tt[a.CP] = a.Names
+				} else {
+					kw[a.CP] = strings.Split(a.Names, " | ")
+				}
+			}
+		}
+		keywords[locale] = kw
+		tts[locale] = tt
+	}
+	return keywords, tts
 }
 
 func mkemojis() error {
 	text, err := fetch("https://unicode.org/Public/emoji/14.0/emoji-test.txt")
 	zli.F(err)
 
-	cldr := readCLDR()
+	cldr, tts := readCLDR()
 
 	fp, err := os.Create("gen_emojis.go")
 	zli.F(err)
@@ -136,9 +233,9 @@ func mkemojis() error {
 			GenderRole = 2
 		)
 
-		tone := false
 		gender := GenderNone
 		var cp []string
+		var toneAt, genderAt, zwjAt []int
 		splitCodepoints := strings.Split(codepoints, " ")
 		for i, c := range splitCodepoints {
 			d, err := strconv.ParseInt(string(c), 16, 64)
@@ -147,12 +244,23 @@ func mkemojis() error {
 			}
 
 			switch d {
-			// Skin tones
+			// Skin tones. Record the slot (how many cp it follows) rather
+			// than just a bool, so two-sided sequences like
+			//
+			//   1F468 1F3FB 200D 1F91D 200D 1F468 1F3FF 👨🏻‍🤝‍👨🏿
+			//   E12.1 men holding hands: light skin tone, dark skin tone
+			//
+			// keep both tones instead of collapsing to "has a tone".
 			case 0x1f3fb, 0x1f3fc, 0x1f3fd, 0x1f3fe, 0x1f3ff:
-				tone = true
-			// ZWJ
+				toneAt = append(toneAt, len(cp))
+			// ZWJ: not part of cp (so the key used to merge tone/gender
+			// variants back onto the base entry stays stable), but its slot
+			// is recorded so it can be woven back into the final Codepoints
+			// by weaveZWJ -- dropping it entirely would render two-sided
+			// sequences like "handshake" as two unjoined emoji instead of
+			// one ligated sequence.
 			case 0x200d:
-				// No nothing
+				zwjAt = append(zwjAt, len(cp))
 
 			// Old/classic gendered emoji. A "person" emoji is combined with "female
 			// sign" or "male sign" to make an explicitly gendered one:
@@ -165,53 +273,21 @@ func mkemojis() error {
 			//   2642                  # ♂ E4.0 male sign
 			//
 			// Detect: 2640 or 2642 occurs in sequence position>0 to exclude just
-			// the female/male signs.
+			// the female/male signs. Also record the slot, for multi-person
+			// sequences (family, kiss, couple with heart) that gender each
+			// side independently.
 			case 0x2640, 0x2642:
 				if i == 0 {
 					cp = append(cp, fmt.Sprintf("0x%x", d))
 				} else {
 					gender = GenderSign
+					genderAt = append(genderAt, len(cp))
 				}
 			default:
 				cp = append(cp, fmt.Sprintf("0x%x", d))
 			}
 		}
 
-		// This ignores combining the "holding hands", "handshake", and
-		// "kissing" with different skin tone variants, where you can select a
-		// different tone for each side (i.e. hand or person):
-		//
-		//   1F468 1F3FB 200D 1F91D 200D 1F468 1F3FF 👨🏻‍🤝‍👨🏿
-		//   E12.1 men holding hands: light skin tone, dark skin tone
-		//
-		//   1F9D1 1F3FB 200D 2764 FE0F 200D 1F48B 200D 1F9D1 1F3FF 🧑🏻‍❤️‍💋‍🧑🏿
-		//   E13.1 kiss: person, person, light skin tone, dark skin tone
-		//
-		// There is no good way to select this with the current UX/flagset; and
-		// to be honest I don't think it's very important either, so just skip
-		// it for now.
-		//
-		// TODO: I guess the best way to fix this is to allow multiple values
-		// for -t and -g:
-		//
-		//   uni e handshake -t dark            Both hands dark
-		//   uni e handshake -t dark -t light   Left hand dark, right hand light
-		//
-		// Actually, I'd change it and make multiple -t and -g flags print
-		// multiple variants (like "-t light,dark" does now), and then change
-		// the meaning of "-t light,dark" to the above to select multiple
-		// variants in the same emoji. That makes more sense, but is not a
-		// backwards-compatible change. Guess we can do it for uni 3.0.
-		if tone && (strings.Contains(name, "holding hands") || strings.Contains(name, "handshake")) {
-			gender = 0
-			tone = false
-			continue
-		}
-		if tone && (strings.Contains(name, "kiss:") || strings.Contains(name, "couple with heart")) {
-			tone = false
-			continue
-		}
-
 		key := strings.Join(cp, ", ")
 
 		// Newer gendered emoji; combine "person", "man", or "women" with
@@ -255,10 +331,11 @@ func mkemojis() error {
 			}
 
 			emojis[key][5] = fmt.Sprintf("%d", gender)
+			emojis[key][6] = intsjoin(genderAt)
 			continue
 		}
 
-		if tone {
+		if len(toneAt) > 0 {
 			_, ok := emojis[key]
 			if !ok && cp[len(cp)-1] == "0xfe0f" {
 				key = strings.Join(cp[0:len(cp)-1], ", ")
@@ -270,12 +347,12 @@ func mkemojis() error {
 				return fmt.Errorf("not found: %q %q", key, name)
 			}
 
-			emojis[key][4] = "true"
+			emojis[key][4] = intsjoin(toneAt)
 			continue
 		}
 
 		emojis[key] = []string{
-			strings.Join(cp, ", "), name, group, subgroup, "false", "0"}
+			strings.Join(cp, ", "), name, group, subgroup, "", "0", "", intsjoin(zwjAt)}
 		order = append(order, key)
 	}
 
@@ -307,15 +384,33 @@ func mkemojis() error {
 			}
 		}
 
+		toneAt := intsparse(e[4])
+		genderAt := intsparse(e[6])
+		zwjAt := intsparse(e[7])
+		cp, toneAt, genderAt = weaveZWJ(cp, toneAt, genderAt, zwjAt)
+
 		emo[i] = unidata.Emoji{
-			Codepoints: cp,
-			Name:       e[1],
-			Group:      groupID,
-			Subgroup:   subgroupID,
-			SkinTones:  e[4] == "true",
-			Genders:    g,
+			Codepoints:  cp,
+			Name:        e[1],
+			Group:       groupID,
+			Subgroup:    subgroupID,
+			ToneSlots:   len(toneAt),
+			ToneAt:      toneAt,
+			Genders:     g,
+			GenderSlots: len(genderAt),
+			GenderAt:    genderAt,
+		}
+		seq := strings.ReplaceAll(strings.ReplaceAll(emo[i].String(), "\ufe0f", ""), "\ufe0e", "")
+		emo[i].CLDR = make(map[string][]string)
+		emo[i].TTS = make(map[string]string)
+		for _, locale := range unidata.Locales {
+			if kw, ok := cldr[locale][seq]; ok {
+				emo[i].CLDR[locale] = kw
+			}
+			if tt, ok := tts[locale][seq]; ok {
+				emo[i].TTS[locale] = tt
+			}
 		}
-		emo[i].CLDR = cldr[strings.ReplaceAll(strings.ReplaceAll(emo[i].String(), "\ufe0f", ""), "\ufe0e", "")]
 	}
 
 	write(fp, "var EmojiGroups = []string{\n")
@@ -342,50 +437,17 @@ func mkemojis() error {
 		}
 		cp = cp[:len(cp)-2]
 
-		//                   CP   Name Grp  Sgr  CLDR sk  gnd
-		write(fp, "\t{[]rune{%s}, %#v, %#v, %#v, %#v, %t, %d},\n",
-			cp, e.Name, e.Group, e.Subgroup, e.CLDR, e.SkinTones, e.Genders)
+		//                   CP   Name Grp  Sgr  CLDR TTS  ToneSlots ToneAt Gnd GenderSlots GenderAt
+		write(fp, "\t{[]rune{%s}, %#v, %#v, %#v, %#v, %#v, %d, %#v, %d, %d, %#v},\n",
+			cp, e.Name, e.Group, e.Subgroup, e.CLDR, e.TTS,
+			e.ToneSlots, e.ToneAt, e.Genders, e.GenderSlots, e.GenderAt)
 	}
 	write(fp, "}\n\n")
 
 	return nil
 }
 
-// TODO: add casefolding
-// https://unicode.org/Public/13.0.0/ucd/CaseFolding.txt
-// CaseFold []rune
-
-// TODO: add properties:
-// https://unicode.org/Public/13.0.0/ucd/PropList.txt
-// "uni p dash" should print all dashes.
-//
-//
-// TODO: add "confusable" information from
-// https://www.unicode.org/Public/idna/13.0.0/
-// and/or
-// https://www.unicode.org/Public/security/13.0.0/
-//
-//
-// TODO: add "alias" information from
-// https://unicode.org/Public/13.0.0/ucd/NamesList.txt
-// This is generated from other sources, but I can't really find where it gts
-// that "x (modifier letter prime - 02B9)" from.
 //
-// 0027	APOSTROPHE
-// 	= apostrophe-quote (1.0)
-// 	= APL quote
-// 	* neutral (vertical) glyph with mixed usage
-// 	* 2019 is preferred for apostrophe
-// 	* preferred characters in English for paired quotation marks are 2018 & 2019
-// 	* 05F3 is preferred for geresh when writing Hebrew
-// 	x (modifier letter prime - 02B9)
-// 	x (modifier letter apostrophe - 02BC)
-// 	x (modifier letter vertical line - 02C8)
-// 	x (combining acute accent - 0301)
-// 	x (hebrew punctuation geresh - 05F3)
-// 	x (prime - 2032)
-// 	x (latin small letter saltillo - A78C)
-
 // http://www.unicode.org/reports/tr44/
 func mkcodepoints() error {
 	text, err := fetch("https://www.unicode.org/Public/UCD/latest/ucd/UnicodeData.txt")
@@ -396,6 +458,8 @@ func mkcodepoints() error {
 		entities = loadentities()
 		digraphs = loaddigraphs()
 		keysyms  = loadkeysyms()
+		casefold = loadcasefold()
+		names    = loadnameslist()
 	)
 
 	fp, err := os.Create("gen_codepoints.go")
@@ -417,9 +481,8 @@ func mkcodepoints() error {
 		s := bytes.Split(line, []byte(";"))
 		// Some properties (most notably control characters) all have the name
 		// as <control>, which isn't very useful. The old (obsolete) Unicode 1
-		// name field has a more useful name.
-		// TODO: add this information from:
-		// https://www.unicode.org/Public/UCD/latest/ucd/NamesList.txt
+		// name field has a more useful name; failing that, NamesList.txt often
+		// has a friendlier "= alias" for it (e.g. "NULL" for U+0000).
 		name := s[1]
 		if name[0] == '<' && len(s[10]) > 1 {
 			name = s[10]
@@ -429,6 +492,11 @@ func mkcodepoints() error {
 		zli.F(err)
 		cp := rune(c)
 
+		nl := names[cp]
+		if name[0] == '<' && len(nl.aliases) > 0 {
+			name = []byte(strings.ToUpper(nl.aliases[0]))
+		}
+
 		entitiy := entities[cp]
 		digraph := digraphs[cp]
 		keysym := ""
@@ -436,12 +504,585 @@ func mkcodepoints() error {
 			keysym = keysyms[cp][0]
 		}
 
-		//             CP     Wid    Cat Name Vim HTML Enti KSym
-		write(fp, "\t0x%x: {0x%[1]x, %d, %d, %#v, %#v, %#v, %#v},\n",
-			cp, widths[cp], unidata.Catmap[string(s[2])], string(name), digraph, entitiy, keysym)
+		var fold string
+		for _, r := range casefold[cp] {
+			fold += fmt.Sprintf("0x%x, ", r)
+		}
+		if fold != "" {
+			fold = "[]rune{" + fold[:len(fold)-2] + "}"
+		} else {
+			fold = "nil"
+		}
+
+		aliases, comments, seeAlso := "nil", "nil", "nil"
+		if len(nl.aliases) > 0 {
+			aliases = fmt.Sprintf("%#v", nl.aliases)
+		}
+		if len(nl.comments) > 0 {
+			comments = fmt.Sprintf("%#v", nl.comments)
+		}
+		if len(nl.seeAlso) > 0 {
+			var rs string
+			for _, r := range nl.seeAlso {
+				rs += fmt.Sprintf("0x%x, ", r)
+			}
+			seeAlso = "[]rune{" + rs[:len(rs)-2] + "}"
+		}
+
+		//             CP     Wid    Cat Name Vim HTML Enti KSym Fold Alias Comment SeeAlso
+		write(fp, "\t0x%x: {0x%[1]x, %d, %d, %#v, %#v, %#v, %#v, %s, %s, %s, %s},\n",
+			cp, widths[cp], unidata.Catmap[string(s[2])], string(name), digraph, entitiy, keysym, fold,
+			aliases, comments, seeAlso)
+	}
+
+	write(fp, "}\n")
+	return nil
+}
+
+// propEntry is a single "XXXX..YYYY ; Prop_Name" (or "XXXX ; Prop_Name") line
+// from PropList.txt, DerivedCoreProperties.txt, Scripts.txt,
+// ScriptExtensions.txt, or Blocks.txt.
+type propEntry struct {
+	lo, hi rune
+	name   string
+}
+
+// mkproperties generates gen_properties.go from PropList.txt,
+// DerivedCoreProperties.txt, Scripts.txt, ScriptExtensions.txt, Blocks.txt,
+// emoji-data.txt, and the two alias files; this backs "uni p".
+func mkproperties() error {
+	aliases := loadpropertyaliases()
+
+	var entries []propEntry
+	for _, u := range []string{
+		"https://www.unicode.org/Public/UCD/latest/ucd/PropList.txt",
+		"https://www.unicode.org/Public/UCD/latest/ucd/DerivedCoreProperties.txt",
+		// Emoji_Presentation, Emoji, Emoji_Modifier(_Base), and
+		// Extended_Pictographic (needed by uni p and, via mkgbp, grapheme
+		// cluster segmentation) aren't in the UCD proper -- they're
+		// published separately alongside emoji-sequences.txt.
+		"https://unicode.org/Public/emoji/14.0/emoji-data.txt",
+	} {
+		entries = append(entries, loadpropfile(u, "")...)
+	}
+	entries = append(entries, loadpropfile(
+		"https://www.unicode.org/Public/UCD/latest/ucd/Scripts.txt", "Script=")...)
+	entries = append(entries, loadpropfile(
+		"https://www.unicode.org/Public/UCD/latest/ucd/ScriptExtensions.txt", "Script=")...)
+	entries = append(entries, loadpropfile(
+		"https://www.unicode.org/Public/UCD/latest/ucd/Blocks.txt", "Block=")...)
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lo < entries[j].lo })
+
+	names := map[string]uint16{}
+	nameFor := func(n string) uint16 {
+		n = canonicalprop(n, aliases)
+		if id, ok := names[n]; ok {
+			return id
+		}
+		id := uint16(len(names))
+		names[n] = id
+		return id
+	}
+
+	fp, err := os.Create("gen_properties.go")
+	zli.F(err)
+	defer func() { zli.F(fp.Close()) }()
+
+	write(fp, "// Code generated by gen.go; DO NOT EDIT\n\n"+
+		"package unidata\n\n"+
+		"var PropertyRanges = []struct {\n"+
+		"\tLo, Hi rune\n"+
+		"\tProp   uint16\n"+
+		"}{\n")
+	for _, e := range entries {
+		write(fp, "\t{0x%x, 0x%x, %d},\n", e.lo, e.hi, nameFor(e.name))
+	}
+	write(fp, "}\n\n")
+
+	write(fp, "var PropertyNames = map[uint16]string{\n")
+	for n, id := range names {
+		write(fp, "\t%d: %#v,\n", id, n)
 	}
+	write(fp, "}\n\n")
 
+	write(fp, "var PropertyAliases = map[string]uint16{\n")
+	for alias, canon := range aliases {
+		if id, ok := names[canon]; ok {
+			write(fp, "\t%#v: %d,\n", alias, id)
+		}
+	}
+	for n, id := range names {
+		write(fp, "\t%#v: %d,\n", n, id)
+	}
 	write(fp, "}\n")
+
+	return nil
+}
+
+// loadpropfile parses the "XXXX..YYYY ; Prop_Name # comment" format shared by
+// PropList.txt, DerivedCoreProperties.txt, Scripts.txt, ScriptExtensions.txt,
+// Blocks.txt, GraphemeBreakProperty.txt, and emoji-data.txt. prefix is
+// prepended to the property name, so Scripts.txt
+// entries become "Script=Greek" rather than just "Greek". Blocks.txt is the
+// odd one out: its value is the whole, possibly multi-word, block name (e.g.
+// "Mathematical Operators"), so it's kept in full rather than truncated to
+// the first field.
+func loadpropfile(url, prefix string) []propEntry {
+	text, err := fetch(url)
+	zli.F(err)
+
+	var out []propEntry
+	for _, line := range strings.Split(string(text), "\n") {
+		if p := strings.Index(line, "#"); p > -1 {
+			line = line[:p]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		s := strings.Split(line, ";")
+		if len(s) < 2 {
+			continue
+		}
+		rng := strings.TrimSpace(s[0])
+
+		var name string
+		if prefix == "Block=" {
+			name = prefix + strings.TrimSpace(s[1])
+		} else {
+			fields := strings.Fields(s[1])
+			if len(fields) == 0 {
+				continue
+			}
+			name = prefix + fields[0]
+		}
+
+		if !strings.Contains(rng, "..") {
+			cp, err := strconv.ParseUint(rng, 16, 32)
+			zli.F(err)
+			out = append(out, propEntry{rune(cp), rune(cp), name})
+			continue
+		}
+
+		sp := strings.Split(rng, "..")
+		lo, err := strconv.ParseUint(sp[0], 16, 32)
+		zli.F(err)
+		hi, err := strconv.ParseUint(sp[1], 16, 32)
+		zli.F(err)
+		out = append(out, propEntry{rune(lo), rune(hi), name})
+	}
+	return out
+}
+
+// loadpropertyaliases reads PropertyAliases.txt and PropertyValueAliases.txt
+// so short/abbreviated names (e.g. "WSpace") resolve to the canonical long
+// name ("White_Space").
+func loadpropertyaliases() map[string]string {
+	aliases := make(map[string]string)
+	for _, url := range []string{
+		"https://www.unicode.org/Public/UCD/latest/ucd/PropertyAliases.txt",
+		"https://www.unicode.org/Public/UCD/latest/ucd/PropertyValueAliases.txt",
+	} {
+		// PropertyAliases.txt rows are "short ; long [; other alias...]".
+		// PropertyValueAliases.txt rows are "prop_key ; short ; long [; other
+		// alias...]" — the leading prop_key (e.g. "sc", "gc") isn't an alias
+		// at all, it just says which property the value belongs to, so it
+		// has to be dropped rather than treated as the short form.
+		valueAliases := strings.HasSuffix(url, "PropertyValueAliases.txt")
+
+		text, err := fetch(url)
+		zli.F(err)
+
+		for _, line := range strings.Split(string(text), "\n") {
+			if p := strings.Index(line, "#"); p > -1 {
+				line = line[:p]
+			}
+			line = strings.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+
+			s := strings.Split(line, ";")
+			for i := range s {
+				s[i] = strings.TrimSpace(s[i])
+			}
+			if valueAliases {
+				if len(s) < 2 {
+					continue
+				}
+				s = s[1:]
+			}
+			if len(s) < 2 {
+				continue
+			}
+
+			// s[0] is the short/abbreviated form, s[1] the canonical long
+			// name; anything after that is a further alternate alias. Always
+			// alias every other form to the long name, never the reverse.
+			long := s[1]
+			for _, short := range s {
+				if short != long {
+					aliases[short] = long
+				}
+			}
+		}
+	}
+	return aliases
+}
+
+// canonicalprop resolves an abbreviated property (or property value) name to
+// its canonical long form, e.g. "WSpace" -> "White_Space".
+func canonicalprop(name string, aliases map[string]string) string {
+	if prefix := "Script="; strings.HasPrefix(name, prefix) {
+		return prefix + canonicalprop(strings.TrimPrefix(name, prefix), aliases)
+	}
+	if long, ok := aliases[name]; ok {
+		return long
+	}
+	return name
+}
+
+// mkcasefold generates gen_casefold.go from CaseFolding.txt (see
+// loadcasefold); SpecialCasing.txt is not read. It returns only an error:
+// mkcodepoints() calls loadcasefold() itself to populate Codepoint.CaseFold.
+func mkcasefold() error {
+	fold := loadcasefold()
+
+	fp, err := os.Create("gen_casefold.go")
+	zli.F(err)
+	defer func() { zli.F(fp.Close()) }()
+
+	write(fp, "// Code generated by gen.go; DO NOT EDIT\n\n"+
+		"package unidata\n\n"+
+		"var CaseFold = map[rune][]rune{\n")
+
+	keys := make([]int, 0, len(fold))
+	for cp := range fold {
+		keys = append(keys, int(cp))
+	}
+	sort.Ints(keys)
+	for _, cp := range keys {
+		var rs string
+		for _, r := range fold[rune(cp)] {
+			rs += fmt.Sprintf("0x%x, ", r)
+		}
+		write(fp, "\t0x%x: {%s},\n", cp, rs[:len(rs)-2])
+	}
+	write(fp, "}\n")
+
+	return nil
+}
+
+// loadcasefold parses CaseFolding.txt for the "C" (common) and "F" (full)
+// statuses, which is what's used for caseless matching in most contexts (see
+// TR44 §5.6). The "T" (Turkic) status is deliberately skipped: it redefines
+// dotted/dotless i and would make folding locale-dependent, which doesn't fit
+// a single static table.
+func loadcasefold() map[rune][]rune {
+	text, err := fetch("https://unicode.org/Public/UCD/latest/ucd/CaseFolding.txt")
+	zli.F(err)
+
+	fold := make(map[rune][]rune)
+	for _, line := range strings.Split(string(text), "\n") {
+		if p := strings.Index(line, "#"); p > -1 {
+			line = line[:p]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		s := strings.Split(line, ";")
+		status := strings.TrimSpace(s[1])
+		if status != "C" && status != "F" {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(strings.TrimSpace(s[0]), 16, 32)
+		zli.F(err)
+
+		var mapping []rune
+		for _, f := range strings.Fields(s[2]) {
+			r, err := strconv.ParseUint(f, 16, 32)
+			zli.F(err)
+			mapping = append(mapping, rune(r))
+		}
+		fold[rune(cp)] = mapping
+	}
+	return fold
+}
+
+// mkconfusables generates gen_confusables.go from confusables.txt and
+// IdentifierStatus.txt, which back "uni confuse" and unidata.Skeleton().
+func mkconfusables() error {
+	confusables := loadconfusables()
+	idstatus := loadidentifierstatus()
+
+	fp, err := os.Create("gen_confusables.go")
+	zli.F(err)
+	defer func() { zli.F(fp.Close()) }()
+
+	write(fp, "// Code generated by gen.go; DO NOT EDIT\n\n"+
+		"package unidata\n\n"+
+		"var Confusables = map[rune][]rune{\n")
+	keys := make([]int, 0, len(confusables))
+	for cp := range confusables {
+		keys = append(keys, int(cp))
+	}
+	sort.Ints(keys)
+	for _, cp := range keys {
+		var rs string
+		for _, r := range confusables[rune(cp)] {
+			rs += fmt.Sprintf("0x%x, ", r)
+		}
+		write(fp, "\t0x%x: {%s},\n", cp, rs[:len(rs)-2])
+	}
+	write(fp, "}\n\n")
+
+	write(fp, "var IdentifierStatus = map[rune]uint8{\n")
+	idkeys := make([]int, 0, len(idstatus))
+	for cp := range idstatus {
+		idkeys = append(idkeys, int(cp))
+	}
+	sort.Ints(idkeys)
+	for _, cp := range idkeys {
+		write(fp, "\t0x%x: %d,\n", cp, idstatus[rune(cp)])
+	}
+	write(fp, "}\n")
+
+	return nil
+}
+
+// loadconfusables parses the Unicode security "confusables.txt" skeleton
+// mapping ("source ; target ; MA # comment").
+func loadconfusables() map[rune][]rune {
+	text, err := fetch("https://www.unicode.org/Public/security/latest/confusables.txt")
+	zli.F(err)
+
+	out := make(map[rune][]rune)
+	for _, line := range strings.Split(string(text), "\n") {
+		if p := strings.Index(line, "#"); p > -1 {
+			line = line[:p]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		s := strings.Split(line, ";")
+		if len(s) < 2 {
+			continue
+		}
+
+		cp, err := strconv.ParseUint(strings.TrimSpace(s[0]), 16, 32)
+		zli.F(err)
+
+		var target []rune
+		for _, f := range strings.Fields(s[1]) {
+			r, err := strconv.ParseUint(f, 16, 32)
+			zli.F(err)
+			target = append(target, rune(r))
+		}
+		out[rune(cp)] = target
+	}
+	return out
+}
+
+// loadidentifierstatus parses IdentifierStatus.txt, which marks every
+// codepoint as either "Allowed" or "Restricted" for use in identifiers.
+func loadidentifierstatus() map[rune]uint8 {
+	text, err := fetch("https://www.unicode.org/Public/security/latest/IdentifierStatus.txt")
+	zli.F(err)
+
+	const (
+		idAllowed    = 0
+		idRestricted = 1
+	)
+
+	out := make(map[rune]uint8)
+	for _, line := range strings.Split(string(text), "\n") {
+		if p := strings.Index(line, "#"); p > -1 {
+			line = line[:p]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		s := strings.Split(line, ";")
+		if len(s) < 2 {
+			continue
+		}
+		rng := strings.TrimSpace(s[0])
+		status := uint8(idRestricted)
+		if strings.TrimSpace(s[1]) == "Allowed" {
+			status = idAllowed
+		}
+
+		if !strings.Contains(rng, "..") {
+			cp, err := strconv.ParseUint(rng, 16, 32)
+			zli.F(err)
+			out[rune(cp)] = status
+			continue
+		}
+
+		sp := strings.Split(rng, "..")
+		lo, err := strconv.ParseUint(sp[0], 16, 32)
+		zli.F(err)
+		hi, err := strconv.ParseUint(sp[1], 16, 32)
+		zli.F(err)
+		for cp := lo; cp <= hi; cp++ {
+			out[rune(cp)] = status
+		}
+	}
+	return out
+}
+
+// namesListEntry holds the alias ("="), comment ("*"), and cross-reference
+// ("x") lines NamesList.txt attaches to a codepoint.
+type namesListEntry struct {
+	aliases  []string
+	comments []string
+	seeAlso  []rune
+}
+
+// xrefRE matches the "(description - XXXX)" tail of a NamesList.txt "x" line,
+// e.g. "x (modifier letter prime - 02B9)".
+var xrefRE = regexp.MustCompile(`- ([0-9A-Fa-f]{4,6})\)\s*$`)
+
+// loadnameslist parses NamesList.txt, which documents aliases ("\t="),
+// annotative comments ("\t*"), and cross-references to similar/confused
+// characters ("\tx") for a codepoint, in addition to its own heading line
+// ("XXXX\tNAME").
+func loadnameslist() map[rune]namesListEntry {
+	text, err := fetch("https://www.unicode.org/Public/UCD/latest/ucd/NamesList.txt")
+	zli.F(err)
+
+	out := make(map[rune]namesListEntry)
+	var cur rune
+	var haveCur bool
+	for _, line := range strings.Split(string(text), "\n") {
+		switch {
+		case strings.HasPrefix(line, "\t="):
+			if !haveCur {
+				continue
+			}
+			e := out[cur]
+			e.aliases = append(e.aliases, strings.TrimSpace(strings.TrimPrefix(line, "\t=")))
+			out[cur] = e
+		case strings.HasPrefix(line, "\t*"):
+			if !haveCur {
+				continue
+			}
+			e := out[cur]
+			e.comments = append(e.comments, strings.TrimSpace(strings.TrimPrefix(line, "\t*")))
+			out[cur] = e
+		case strings.HasPrefix(line, "\tx"):
+			if !haveCur {
+				continue
+			}
+			m := xrefRE.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			r, err := strconv.ParseUint(m[1], 16, 32)
+			zli.F(err)
+			e := out[cur]
+			e.seeAlso = append(e.seeAlso, rune(r))
+			out[cur] = e
+		case len(line) > 0 && line[0] != '\t' && line[0] != '@':
+			f := strings.Fields(line)
+			if len(f) < 2 {
+				haveCur = false
+				continue
+			}
+			r, err := strconv.ParseUint(f[0], 16, 32)
+			if err != nil {
+				haveCur = false
+				continue
+			}
+			cur, haveCur = rune(r), true
+		}
+	}
+	return out
+}
+
+// Grapheme_Cluster_Break values, as per UAX #29. Extended_Pictographic isn't
+// a Grapheme_Cluster_Break value itself, but emoji-data.txt defines it with
+// the same "XXXX..YYYY ; Name" shape and GB11 needs it, so it's folded into
+// the same table. mkgbp writes the same constants into gen_gbp.go, for
+// package unidata.
+const (
+	GBPOther = iota
+	GBPCR
+	GBPLF
+	GBPControl
+	GBPExtend
+	GBPZWJ
+	GBPRegionalIndicator
+	GBPPrepend
+	GBPSpacingMark
+	GBPL
+	GBPV
+	GBPT
+	GBPLV
+	GBPLVT
+	GBPExtendedPictographic
+)
+
+var gbpNames = map[string]uint8{
+	"CR":                    GBPCR,
+	"LF":                    GBPLF,
+	"Control":               GBPControl,
+	"Extend":                GBPExtend,
+	"ZWJ":                   GBPZWJ,
+	"Regional_Indicator":    GBPRegionalIndicator,
+	"Prepend":               GBPPrepend,
+	"SpacingMark":           GBPSpacingMark,
+	"L":                     GBPL,
+	"V":                     GBPV,
+	"T":                     GBPT,
+	"LV":                    GBPLV,
+	"LVT":                   GBPLVT,
+	"Extended_Pictographic": GBPExtendedPictographic,
+}
+
+// mkgbp generates gen_gbp.go from GraphemeBreakProperty.txt and
+// emoji-data.txt (for Extended_Pictographic); this backs the grapheme
+// cluster segmentation in unidata/width.
+func mkgbp() error {
+	var entries []propEntry
+	entries = append(entries, loadpropfile(
+		"https://www.unicode.org/Public/UCD/latest/ucd/auxiliary/GraphemeBreakProperty.txt", "")...)
+	entries = append(entries, loadpropfile(
+		"https://unicode.org/Public/emoji/14.0/emoji-data.txt", "")...)
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lo < entries[j].lo })
+
+	fp, err := os.Create("gen_gbp.go")
+	zli.F(err)
+	defer func() { zli.F(fp.Close()) }()
+
+	write(fp, "// Code generated by gen.go; DO NOT EDIT\n\n"+
+		"package unidata\n\n"+
+		"const (\n\tGBPOther = iota\n\tGBPCR\n\tGBPLF\n\tGBPControl\n\tGBPExtend\n\tGBPZWJ\n"+
+		"\tGBPRegionalIndicator\n\tGBPPrepend\n\tGBPSpacingMark\n\tGBPL\n\tGBPV\n\tGBPT\n"+
+		"\tGBPLV\n\tGBPLVT\n\tGBPExtendedPictographic\n)\n\n"+
+		"var GBPRanges = []struct {\n"+
+		"\tLo, Hi rune\n"+
+		"\tProp   uint8\n"+
+		"}{\n")
+	for _, e := range entries {
+		prop, ok := gbpNames[e.name]
+		if !ok {
+			continue
+		}
+		write(fp, "\t{0x%x, 0x%x, %d},\n", e.lo, e.hi, prop)
+	}
+	write(fp, "}\n")
+
 	return nil
 }
 
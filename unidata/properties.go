@@ -0,0 +1,74 @@
+package unidata
+
+import "strings"
+
+// looseProp applies the UAX44-LM3 "loose matching" rule for property and
+// property-value names: case-fold, ignore spaces, underscores, and hyphens,
+// and drop an initial "is", so "dash", "Dash", and "is-Dash" all resolve to
+// the same property. This is applied to both the query and the generated
+// PropertyAliases keys, so the exact spelling/casing Unicode happens to use
+// for a given alias doesn't matter.
+func looseProp(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer(" ", "", "_", "", "-", "").Replace(s)
+	return strings.TrimPrefix(s, "is")
+}
+
+// looseAliases maps looseProp(alias) to the property ID, built once from the
+// generated PropertyAliases so lookups can loose-match per UAX44-LM3.
+var looseAliases = func() map[string]uint16 {
+	m := make(map[string]uint16, len(PropertyAliases))
+	for alias, id := range PropertyAliases {
+		m[looseProp(alias)] = id
+	}
+	return m
+}()
+
+// ResolveProperty looks up name (loose-matched per UAX44-LM3) in
+// PropertyAliases, returning its property ID.
+func ResolveProperty(name string) (uint16, bool) {
+	id, ok := looseAliases[looseProp(name)]
+	return id, ok
+}
+
+// PropertiesOf returns the Unicode property names (e.g. "White_Space",
+// "Dash", "Script=Greek") that apply to r.
+//
+// PropertyRanges is sorted by Lo, but properties routinely overlap (e.g. a
+// codepoint is both "Alphabetic" and "Script=Latin", and every codepoint
+// falls inside some "Block=" range that spans many smaller PropList/Scripts
+// ranges with a larger Lo). That means Hi isn't monotonic once sorted by Lo,
+// so a range with a match can sit anywhere before r in the slice — this has
+// to scan every range rather than binary-searching down to a single start
+// point.
+func PropertiesOf(r rune) []string {
+	var props []string
+	for _, rng := range PropertyRanges {
+		if rng.Lo <= r && r <= rng.Hi {
+			props = append(props, PropertyNames[rng.Prop])
+		}
+	}
+	return props
+}
+
+// CodepointsWithProperty returns every codepoint with the property name,
+// which may be an alias (e.g. "WSpace") or a "Script=" / "Block=" value (e.g.
+// "Script=Greek", "Block=Mathematical Operators"), loose-matched per
+// UAX44-LM3 (so "dash", "Dash", and "is_Dash" all work).
+func CodepointsWithProperty(name string) []rune {
+	id, ok := ResolveProperty(name)
+	if !ok {
+		return nil
+	}
+
+	var cp []rune
+	for _, rng := range PropertyRanges {
+		if rng.Prop != id {
+			continue
+		}
+		for r := rng.Lo; r <= rng.Hi; r++ {
+			cp = append(cp, r)
+		}
+	}
+	return cp
+}
@@ -0,0 +1,129 @@
+// Package width calculates how many terminal columns a string occupies,
+// grouping it into grapheme clusters first so combining marks, ZWJ sequences,
+// regional indicator (flag) pairs, and skin-tone modifiers are counted as a
+// single unit rather than one column per codepoint.
+package width
+
+import (
+	xwidth "golang.org/x/text/width"
+	"zgo.at/uni/v2/unidata"
+)
+
+// GraphemeClusters splits s into extended grapheme clusters, as per UAX #29
+// rules GB1–GB13 (Unicode 14.0). This isn't locale-sensitive (GB9c/GB999 only
+// cover scripts where tailoring isn't needed for our purposes).
+func GraphemeClusters(s string) []string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return nil
+	}
+
+	var (
+		clusters []string
+		start    int
+		riCount  int // run of consecutive Regional_Indicator runes (GB12/GB13)
+	)
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || breaks(runes[i-1], runes[i], &riCount) {
+			clusters = append(clusters, string(runes[start:i]))
+			start = i
+		}
+	}
+	return clusters
+}
+
+// breaks reports whether there is a grapheme cluster boundary between prev
+// and next. riCount tracks the number of Regional_Indicator runes seen in an
+// unbroken run, needed for the "pair up flags" rule GB12/GB13.
+func breaks(prev, next rune, riCount *int) bool {
+	p, n := unidata.GBProp(prev), unidata.GBProp(next)
+
+	if p == unidata.GBPRegionalIndicator {
+		*riCount++
+	} else {
+		*riCount = 0
+	}
+
+	switch {
+	case p == unidata.GBPCR && n == unidata.GBPLF: // GB3
+		return false
+	case p == unidata.GBPCR || p == unidata.GBPLF || p == unidata.GBPControl: // GB4
+		return true
+	case n == unidata.GBPCR || n == unidata.GBPLF || n == unidata.GBPControl: // GB5
+		return true
+	case p == unidata.GBPL && (n == unidata.GBPL || n == unidata.GBPV || n == unidata.GBPLV || n == unidata.GBPLVT): // GB6
+		return false
+	case (p == unidata.GBPLV || p == unidata.GBPV) && (n == unidata.GBPV || n == unidata.GBPT): // GB7
+		return false
+	case (p == unidata.GBPLVT || p == unidata.GBPT) && n == unidata.GBPT: // GB8
+		return false
+	case n == unidata.GBPExtend || n == unidata.GBPZWJ: // GB9
+		return false
+	case n == unidata.GBPSpacingMark: // GB9a
+		return false
+	case p == unidata.GBPPrepend: // GB9b
+		return false
+	case p == unidata.GBPZWJ && n == unidata.GBPExtendedPictographic: // GB11 (simplified: doesn't check \p{Extended_Pictographic} Extend* before the ZWJ)
+		return false
+	case p == unidata.GBPRegionalIndicator && n == unidata.GBPRegionalIndicator && *riCount%2 == 1: // GB12/GB13
+		return false
+	default: // GB999
+		return true
+	}
+}
+
+// StringWidth returns the number of terminal columns s occupies: each
+// grapheme cluster counts for 2 columns if it's an emoji presentation (ZWJ
+// sequence, skin-tone modifier, flag pair, …) or its base codepoint is
+// fullwidth/wide, and 1 otherwise. A multi-rune cluster isn't automatically
+// wide — an ordinary Latin letter plus a combining diacritic is one
+// grapheme cluster too, but renders as a single narrow column in every
+// terminal.
+func StringWidth(s string) int {
+	w := 0
+	for _, cl := range GraphemeClusters(s) {
+		w += clusterWidth(cl)
+	}
+	return w
+}
+
+func clusterWidth(cl string) int {
+	runes := []rune(cl)
+
+	// Regional indicator pairs (flags) always render as one wide glyph,
+	// regardless of the EastAsianWidth of the individual symbols.
+	if len(runes) == 2 &&
+		unidata.GBProp(runes[0]) == unidata.GBPRegionalIndicator &&
+		unidata.GBProp(runes[1]) == unidata.GBPRegionalIndicator {
+		return 2
+	}
+
+	// ZWJ sequences and emoji with a skin-tone/variation-selector modifier
+	// are wide too; detect them by the presence of a ZWJ or an
+	// Extended_Pictographic codepoint anywhere in the cluster.
+	for _, r := range runes {
+		if r == 0x200d || unidata.GBProp(r) == unidata.GBPExtendedPictographic {
+			return 2
+		}
+	}
+
+	// Anything else is an ordinary base codepoint optionally followed by
+	// combining marks (Extend/SpacingMark), which don't add columns of
+	// their own — only the base codepoint's width matters. Codepoints that
+	// UnicodeData.txt only lists as part of a range (e.g. CJK ideographs)
+	// aren't in unidata.Codepoints, so fall back to their East Asian Width.
+	cp, ok := unidata.Codepoints[runes[0]]
+	if !ok {
+		switch xwidth.LookupRune(runes[0]).Kind() {
+		case xwidth.EastAsianWide, xwidth.EastAsianFullwidth:
+			return 2
+		}
+		return 1
+	}
+	switch cp.Width {
+	case unidata.WidthWide, unidata.WidthFullWidth:
+		return 2
+	default:
+		return 1
+	}
+}
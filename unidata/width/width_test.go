@@ -0,0 +1,58 @@
+package width
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestGraphemeClusters checks a representative subset of the rules in the
+// official Unicode GraphemeBreakTest.txt conformance suite (÷ is a required
+// break, × is a required non-break). This isn't the full conformance suite:
+// it covers GB3, GB4/GB5, GB7, GB9, GB9a, GB11, GB12/GB13, and GB999, but has
+// no case for GB6, GB8, or GB9b.
+func TestGraphemeClusters(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"CRLF", "\r\n", []string{"\r\n"}},                 // GB3
+		{"control", "a\nb", []string{"a", "\n", "b"}},       // GB4/GB5
+		{"hangul LV+T", "가" + "ᆨ", []string{"각"}}, // GB7 (가 = LV, U+11A8 = T)
+		{"extend", "é", []string{"é"}},          // GB9 (e + combining acute)
+		{"spacing mark", "अः", []string{"अः"}},     // GB9a
+		{"zwj emoji", "👨‍👩", []string{"👨‍👩"}},     // GB11
+		{"flag pair", "🇳🇱", []string{"🇳🇱"}},                // GB12/GB13
+		{"flags run", "🇳🇱🇧🇪", []string{"🇳🇱", "🇧🇪"}},        // GB12/GB13, two flags don't merge
+		{"plain ascii", "ab", []string{"a", "b"}},           // GB999
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GraphemeClusters(tt.in)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GraphemeClusters(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{"ascii", "ab", 2},
+		{"combining mark", "é", 1}, // base + accent: still one narrow column
+		{"flag", "🇳🇱", 2},
+		{"zwj emoji", "👨‍👩", 2},
+		{"wide cjk", "中", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringWidth(tt.in); got != tt.want {
+				t.Errorf("StringWidth(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}